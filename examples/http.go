@@ -0,0 +1,83 @@
+package examples
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+)
+
+// WriteJsonResponse writes a standardized JSON envelope directly to an
+// http.ResponseWriter. Unlike JsonResponse, it streams the payload via
+// json.NewEncoder instead of building an intermediate indented string,
+// so it can be mounted straight into net/http, chi, or gin handlers
+// without an extra allocation per request.
+func WriteJsonResponse(w http.ResponseWriter, data interface{}, status int, message string) error {
+    if status < 100 || status > 599 {
+        return fmt.Errorf("status must be a valid HTTP status code (100-599)")
+    }
+
+    response := Response{
+        Status:  status,
+        Message: message,
+        Data:    data,
+    }
+
+    w.Header().Set("Content-Type", "application/json; charset=utf-8")
+    w.WriteHeader(status)
+
+    if err := json.NewEncoder(w).Encode(response); err != nil {
+        return fmt.Errorf("failed to encode JSON: %w", err)
+    }
+    return nil
+}
+
+// ParseError describes a failure to parse an HTTP request body into a
+// Go value, carrying the offending field and expected type when the
+// underlying decoder exposes them.
+type ParseError struct {
+    Field string
+    Type  string
+    Err   error
+}
+
+func (e *ParseError) Error() string {
+    if e.Field != "" {
+        return fmt.Sprintf("parsing error on field %q (expected %s): %s", e.Field, e.Type, e.Err)
+    }
+    return fmt.Sprintf("parsing error: %s", e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+    return e.Err
+}
+
+// ParseJsonRequest reads r's body and unmarshals it into v, dispatching
+// on Content-Type. Only JSON is supported today; the dispatch leaves
+// room to add form/multipart parsing later without changing call sites.
+func ParseJsonRequest(r *http.Request, v interface{}) error {
+    defer r.Body.Close()
+
+    contentType := r.Header.Get("Content-Type")
+    switch {
+    case contentType == "", strings.HasPrefix(contentType, "application/json"):
+        body, err := io.ReadAll(r.Body)
+        if err != nil {
+            return &ParseError{Err: fmt.Errorf("failed to read request body: %w", err)}
+        }
+        if err := json.Unmarshal(body, v); err != nil {
+            return fieldErrorFromJSON(err, body)
+        }
+        return nil
+    default:
+        return &ParseError{Err: fmt.Errorf("unsupported Content-Type: %s", contentType)}
+    }
+}
+
+func fieldErrorFromJSON(err error, body []byte) error {
+    if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+        return &ParseError{Field: typeErr.Field, Type: typeErr.Type.String(), Err: HumanizeJsonError(err, body)}
+    }
+    return &ParseError{Err: HumanizeJsonError(err, body)}
+}
@@ -0,0 +1,64 @@
+package examples
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+)
+
+// HumanizedJsonError wraps a JSON decoding error with the 1-based line
+// and character at which it occurred, so callers can surface pinpoint
+// diagnostics instead of encoding/json's raw byte-offset messages.
+type HumanizedJsonError struct {
+    Line      int
+    Character int
+    Err       error
+}
+
+func (e *HumanizedJsonError) Error() string {
+    return fmt.Sprintf("parsing error at line %d, character %d: %s", e.Line, e.Character, e.Err)
+}
+
+func (e *HumanizedJsonError) Unwrap() error {
+    return e.Err
+}
+
+// HumanizeJsonError converts a *json.SyntaxError or
+// *json.UnmarshalTypeError returned while decoding data into a
+// HumanizedJsonError carrying the 1-based line and character of the
+// failure. Errors of any other type are returned unchanged.
+func HumanizeJsonError(err error, data []byte) error {
+    var offset int64
+    switch e := err.(type) {
+    case *json.SyntaxError:
+        offset = e.Offset
+    case *json.UnmarshalTypeError:
+        offset = e.Offset
+    default:
+        return err
+    }
+
+    // Offset can land past len(data) (e.g. an error at EOF); clamp so
+    // the scan below stays in bounds and still reports the last line.
+    if offset > int64(len(data)) {
+        offset = int64(len(data))
+    }
+
+    head := data[:offset]
+    line := 1 + bytes.Count(head, []byte{'\n'})
+    character := int(offset)
+    if idx := bytes.LastIndexByte(head, '\n'); idx != -1 {
+        character = int(offset) - idx - 1
+    }
+
+    return &HumanizedJsonError{Line: line, Character: character, Err: err}
+}
+
+// ParseInto unmarshals data into v, returning a HumanizedJsonError with
+// line/character location when decoding fails.
+func ParseInto(data []byte, v interface{}) error {
+    if err := json.Unmarshal(data, v); err != nil {
+        return HumanizeJsonError(err, data)
+    }
+    return nil
+}
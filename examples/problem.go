@@ -0,0 +1,91 @@
+package examples
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+)
+
+// Problem is an RFC 7807 "Problem Details for HTTP APIs" envelope,
+// offered as a standards-compliant alternative to Response for callers
+// who need machine-readable error types rather than a free-form
+// message. Errors holds nested sub-problems, mirroring APIs that report
+// several independent failures from a single request.
+type Problem struct {
+    Type     string    `json:"type,omitempty"`
+    Title    string    `json:"title,omitempty"`
+    Status   int       `json:"status,omitempty"`
+    Detail   string    `json:"detail,omitempty"`
+    Instance string    `json:"instance,omitempty"`
+    Errors   []Problem `json:"errors,omitempty"`
+}
+
+// Error implements the error interface so a Problem can be returned
+// directly from a Go handler and matched with errors.As/IsProblemType.
+func (p Problem) Error() string {
+    if p.Detail != "" {
+        return fmt.Sprintf("%s: %s", p.Title, p.Detail)
+    }
+    return p.Title
+}
+
+// ProblemResponse creates a formatted RFC 7807 JSON response string.
+// If problem.Status is unset it defaults to status.
+func ProblemResponse(status int, problem Problem) (string, error) {
+    if status < 100 || status > 599 {
+        return "", fmt.Errorf("status must be a valid HTTP status code (100-599)")
+    }
+    if problem.Status == 0 {
+        problem.Status = status
+    }
+
+    jsonString, err := json.MarshalIndent(problem, "", "  ")
+    if err != nil {
+        return "", fmt.Errorf("failed to marshal JSON: %w", err)
+    }
+
+    return string(jsonString), nil
+}
+
+// IsProblemType reports whether err is a Problem (or wraps one, per
+// errors.As) whose Type equals t.
+func IsProblemType(err error, t string) bool {
+    var p Problem
+    if !errors.As(err, &p) {
+        return false
+    }
+    return p.Type == t
+}
+
+// ProblemTypeRegistry associates short symbolic codes (e.g.
+// "processing-failure/destination-exists") with the URI and default
+// title used to populate a Problem's Type and Title, so call sites can
+// refer to problems by code instead of repeating URIs everywhere.
+type ProblemTypeRegistry struct {
+    entries map[string]problemType
+}
+
+type problemType struct {
+    uri   string
+    title string
+}
+
+// NewProblemTypeRegistry creates an empty ProblemTypeRegistry.
+func NewProblemTypeRegistry() *ProblemTypeRegistry {
+    return &ProblemTypeRegistry{entries: make(map[string]problemType)}
+}
+
+// Register associates code with a problem type URI and default title.
+func (r *ProblemTypeRegistry) Register(code, uri, title string) {
+    r.entries[code] = problemType{uri: uri, title: title}
+}
+
+// New builds a Problem for the given registered code, applying its URI
+// and default title. An unregistered code is used verbatim as Type.
+func (r *ProblemTypeRegistry) New(code string, status int, detail string) Problem {
+    entry, ok := r.entries[code]
+    if !ok {
+        return Problem{Type: code, Status: status, Detail: detail}
+    }
+    return Problem{Type: entry.uri, Title: entry.title, Status: status, Detail: detail}
+}
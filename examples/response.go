@@ -4,13 +4,30 @@ package examples
 import (
     "encoding/json"
     "fmt"
+    "strings"
 )
 
 // Response defines the structure of the JSON output.
 type Response struct {
-    Status  int         `json:"status"`
-    Message string      `json:"message"`
-    Data    interface{} `json:"data"`
+    Status  int          `json:"status"`
+    Message string       `json:"message"`
+    Data    interface{}  `json:"data"`
+    Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// Error implements the error interface so a Response carrying field
+// errors can be returned directly from a Go handler as its error value.
+// The message is Message, followed by each field error when there are any.
+func (r Response) Error() string {
+    if len(r.Errors) == 0 {
+        return r.Message
+    }
+
+    parts := make([]string, 0, len(r.Errors))
+    for _, fe := range r.Errors {
+        parts = append(parts, fe.Error())
+    }
+    return fmt.Sprintf("%s: %s", r.Message, strings.Join(parts, "; "))
 }
 
 // JsonResponse creates a formatted JSON response string with zero dependencies.
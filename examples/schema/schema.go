@@ -0,0 +1,230 @@
+// Package schema adds optional JSON Schema validation on top of the
+// core examples package. It is kept separate so that basic usage of
+// JsonResponse stays free of reflection-based validation: only callers
+// who import examples/schema pull this in.
+package schema
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+    "reflect"
+    "strconv"
+    "strings"
+
+    response "github.com/EELLOOII/json-response-standard/go-lib/examples"
+)
+
+// Schema is a minimal JSON Schema subset sufficient to validate a
+// Response's Data payload before it is serialized.
+type Schema struct {
+    Type       string             `json:"type"`
+    Properties map[string]*Schema `json:"properties,omitempty"`
+    Required   []string           `json:"required,omitempty"`
+    Items      *Schema            `json:"items,omitempty"`
+    Minimum    *float64           `json:"minimum,omitempty"`
+    Maximum    *float64           `json:"maximum,omitempty"`
+}
+
+// JsonResponseWithSchema validates data against schema before
+// marshaling it into the standard success envelope. On validation
+// failure it returns a JsonValidationResponse (HTTP 422) describing
+// each violated field instead of the success envelope.
+func JsonResponseWithSchema(data interface{}, status int, message string, schema *Schema) (string, error) {
+    if status < 100 || status > 599 {
+        return "", fmt.Errorf("status must be a valid HTTP status code (100-599)")
+    }
+    if schema == nil {
+        return "", fmt.Errorf("schema must not be nil")
+    }
+
+    if fieldErrors := schema.Validate(data); len(fieldErrors) > 0 {
+        return response.JsonValidationResponse(422, "validation failed", fieldErrors)
+    }
+
+    return response.JsonResponse(data, status, message)
+}
+
+// Validate checks data against the schema, returning one FieldError per
+// violated property rather than stopping at the first failure. data is
+// round-tripped through encoding/json so validation operates on the
+// same representation the caller's JSON consumers will see.
+func (s *Schema) Validate(data interface{}) []response.FieldError {
+    if s == nil {
+        return []response.FieldError{{Parameter: "", Errors: []string{"schema must not be nil"}}}
+    }
+
+    encoded, err := json.Marshal(data)
+    if err != nil {
+        return []response.FieldError{{Parameter: "", Errors: []string{fmt.Sprintf("failed to marshal data: %s", err)}}}
+    }
+
+    var generic interface{}
+    if err := json.Unmarshal(encoded, &generic); err != nil {
+        return []response.FieldError{{Parameter: "", Errors: []string{fmt.Sprintf("failed to decode data: %s", err)}}}
+    }
+
+    var errs []response.FieldError
+    s.validate("", generic, &errs)
+    return errs
+}
+
+func (s *Schema) validate(path string, v interface{}, errs *[]response.FieldError) {
+    switch s.Type {
+    case "object":
+        m, ok := v.(map[string]interface{})
+        if !ok {
+            addFieldError(errs, path, "expected an object")
+            return
+        }
+        for _, name := range s.Required {
+            if _, present := m[name]; !present {
+                addFieldError(errs, joinPath(path, name), "is required")
+            }
+        }
+        for name, propSchema := range s.Properties {
+            child, present := m[name]
+            if !present {
+                continue
+            }
+            propSchema.validate(joinPath(path, name), child, errs)
+        }
+    case "array":
+        arr, ok := v.([]interface{})
+        if !ok {
+            addFieldError(errs, path, "expected an array")
+            return
+        }
+        if s.Items == nil {
+            addFieldError(errs, path, "schema has no items definition")
+            return
+        }
+        for i, item := range arr {
+            s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+        }
+    case "number":
+        f, ok := v.(float64)
+        if !ok {
+            addFieldError(errs, path, "expected a number")
+            return
+        }
+        s.checkRange(path, f, errs)
+    case "integer":
+        f, ok := v.(float64)
+        if !ok {
+            addFieldError(errs, path, "expected a number")
+            return
+        }
+        if f != math.Trunc(f) {
+            addFieldError(errs, path, "expected an integer")
+            return
+        }
+        s.checkRange(path, f, errs)
+    case "string":
+        if _, ok := v.(string); !ok {
+            addFieldError(errs, path, "expected a string")
+        }
+    case "boolean":
+        if _, ok := v.(bool); !ok {
+            addFieldError(errs, path, "expected a boolean")
+        }
+    }
+}
+
+func (s *Schema) checkRange(path string, f float64, errs *[]response.FieldError) {
+    if s.Minimum != nil && f < *s.Minimum {
+        addFieldError(errs, path, fmt.Sprintf("must be >= %v", *s.Minimum))
+    }
+    if s.Maximum != nil && f > *s.Maximum {
+        addFieldError(errs, path, fmt.Sprintf("must be <= %v", *s.Maximum))
+    }
+}
+
+func joinPath(path, name string) string {
+    if path == "" {
+        return name
+    }
+    return path + "." + name
+}
+
+func addFieldError(errs *[]response.FieldError, path, msg string) {
+    *errs = append(*errs, response.FieldError{Parameter: path, Errors: []string{msg}})
+}
+
+// GenerateSchemaForType walks t's struct tags (json, validate) to
+// produce a Schema, so callers don't have to hand-write one for types
+// they already use elsewhere. Supported validate rules are "required",
+// "min=N", and "max=N".
+func GenerateSchemaForType(t reflect.Type) (*Schema, error) {
+    for t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+
+    switch t.Kind() {
+    case reflect.Struct:
+        return generateStructSchema(t)
+    case reflect.Slice, reflect.Array:
+        elem, err := GenerateSchemaForType(t.Elem())
+        if err != nil {
+            return nil, err
+        }
+        return &Schema{Type: "array", Items: elem}, nil
+    case reflect.String:
+        return &Schema{Type: "string"}, nil
+    case reflect.Bool:
+        return &Schema{Type: "boolean"}, nil
+    case reflect.Float32, reflect.Float64:
+        return &Schema{Type: "number"}, nil
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return &Schema{Type: "integer"}, nil
+    default:
+        return nil, fmt.Errorf("unsupported type for schema generation: %s", t.Kind())
+    }
+}
+
+func generateStructSchema(t reflect.Type) (*Schema, error) {
+    s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        if field.PkgPath != "" {
+            continue
+        }
+
+        name := field.Name
+        if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+            parts := strings.Split(jsonTag, ",")
+            if parts[0] == "-" {
+                continue
+            }
+            if parts[0] != "" {
+                name = parts[0]
+            }
+        }
+
+        fieldSchema, err := GenerateSchemaForType(field.Type)
+        if err != nil {
+            return nil, fmt.Errorf("field %q: %w", field.Name, err)
+        }
+
+        for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+            switch {
+            case rule == "required":
+                s.Required = append(s.Required, name)
+            case strings.HasPrefix(rule, "min="):
+                if min, err := strconv.ParseFloat(strings.TrimPrefix(rule, "min="), 64); err == nil {
+                    fieldSchema.Minimum = &min
+                }
+            case strings.HasPrefix(rule, "max="):
+                if max, err := strconv.ParseFloat(strings.TrimPrefix(rule, "max="), 64); err == nil {
+                    fieldSchema.Maximum = &max
+                }
+            }
+        }
+
+        s.Properties[name] = fieldSchema
+    }
+
+    return s, nil
+}
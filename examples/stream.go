@@ -0,0 +1,90 @@
+package examples
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+)
+
+// StreamWriter emits a response as newline-delimited JSON (NDJSON): a
+// single metadata line carrying status/message, followed by one data
+// line per item pushed via Write. This avoids JsonResponse's
+// whole-payload MarshalIndent, so large result sets (log tails,
+// exports, search results) don't have to be buffered in memory.
+type StreamWriter struct {
+    enc     *json.Encoder
+    flusher http.Flusher
+    closed  bool
+    err     error
+}
+
+// NewJsonStreamWriter creates a StreamWriter over w and immediately
+// writes the envelope's status/message metadata line. A failure to
+// write that line is recorded and returned by the first subsequent
+// Write or Close call.
+func NewJsonStreamWriter(w io.Writer, status int, message string) *StreamWriter {
+    sw := &StreamWriter{enc: json.NewEncoder(w)}
+    if f, ok := w.(http.Flusher); ok {
+        sw.flusher = f
+    }
+
+    meta := struct {
+        Status  int    `json:"status"`
+        Message string `json:"message"`
+    }{Status: status, Message: message}
+
+    if err := sw.enc.Encode(meta); err != nil {
+        sw.err = fmt.Errorf("failed to write stream header: %w", err)
+        return sw
+    }
+    sw.flush()
+    return sw
+}
+
+// Write frames item as a single NDJSON data line and flushes it if the
+// underlying writer supports flushing.
+func (sw *StreamWriter) Write(item interface{}) error {
+    if sw.err != nil {
+        return sw.err
+    }
+    if sw.closed {
+        return fmt.Errorf("stream writer is closed")
+    }
+
+    if err := sw.enc.Encode(item); err != nil {
+        sw.err = fmt.Errorf("failed to encode stream item: %w", err)
+        return sw.err
+    }
+    sw.flush()
+    return nil
+}
+
+// Close marks the stream as finished and returns any error recorded by
+// a prior Write or by the initial header write. No trailing frame is
+// written; callers detect the end of the stream when the connection
+// closes.
+func (sw *StreamWriter) Close() error {
+    sw.closed = true
+    return sw.err
+}
+
+func (sw *StreamWriter) flush() {
+    if sw.flusher != nil {
+        sw.flusher.Flush()
+    }
+}
+
+// WriteJsonStream prepares w for an NDJSON response: it sets the
+// x-ndjson content type, writes status, and returns a StreamWriter
+// already primed with the envelope's status/message metadata line.
+func WriteJsonStream(w http.ResponseWriter, status int, message string) (*StreamWriter, error) {
+    if status < 100 || status > 599 {
+        return nil, fmt.Errorf("status must be a valid HTTP status code (100-599)")
+    }
+
+    w.Header().Set("Content-Type", "application/x-ndjson")
+    w.WriteHeader(status)
+
+    return NewJsonStreamWriter(w, status, message), nil
+}
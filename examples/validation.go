@@ -0,0 +1,66 @@
+package examples
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+)
+
+// FieldError describes a validation failure on a single input
+// parameter, which may be a flat form field ("email") or a nested JSON
+// path ("user.email", "items[0].price").
+type FieldError struct {
+    Parameter string   `json:"parameter"`
+    Errors    []string `json:"errors"`
+}
+
+// Error returns the field's messages joined for logging or display.
+func (fe FieldError) Error() string {
+    return fmt.Sprintf("%s: %s", fe.Parameter, strings.Join(fe.Errors, "; "))
+}
+
+// JsonValidationResponse creates a formatted JSON response envelope
+// carrying field-level validation errors instead of a Data payload.
+// Duplicate parameters in fieldErrors are merged via MergeFieldErrors.
+func JsonValidationResponse(status int, message string, fieldErrors []FieldError) (string, error) {
+    if status < 100 || status > 599 {
+        return "", fmt.Errorf("status must be a valid HTTP status code (100-599)")
+    }
+
+    response := Response{
+        Status:  status,
+        Message: message,
+        Errors:  MergeFieldErrors(fieldErrors...),
+    }
+
+    jsonString, err := json.MarshalIndent(response, "", "  ")
+    if err != nil {
+        return "", fmt.Errorf("failed to marshal JSON: %w", err)
+    }
+
+    return string(jsonString), nil
+}
+
+// MergeFieldErrors consolidates fieldErrors by Parameter, preserving
+// first-seen order and concatenating the Errors of duplicate
+// parameters instead of dropping or overwriting them.
+func MergeFieldErrors(fieldErrors ...FieldError) []FieldError {
+    if len(fieldErrors) == 0 {
+        return nil
+    }
+
+    order := make([]string, 0, len(fieldErrors))
+    messages := make(map[string][]string, len(fieldErrors))
+    for _, fe := range fieldErrors {
+        if _, seen := messages[fe.Parameter]; !seen {
+            order = append(order, fe.Parameter)
+        }
+        messages[fe.Parameter] = append(messages[fe.Parameter], fe.Errors...)
+    }
+
+    merged := make([]FieldError, 0, len(order))
+    for _, parameter := range order {
+        merged = append(merged, FieldError{Parameter: parameter, Errors: messages[parameter]})
+    }
+    return merged
+}
@@ -3,10 +3,14 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 
 	response "github.com/EELLOOII/json-response-standard/go-lib/examples"
+	"github.com/EELLOOII/json-response-standard/go-lib/examples/schema"
 )
 
 type Response = response.Response
@@ -207,6 +211,408 @@ func main() {
 		return nil
 	})
 
+	// Test 9: WriteJsonResponse writes status, headers and body
+	test("WriteJsonResponse writes status, headers and body", func() error {
+		rec := httptest.NewRecorder()
+		err := response.WriteJsonResponse(rec, map[string]interface{}{"user": "John"}, 201, "Created")
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		if rec.Code != 201 {
+			return fmt.Errorf("status should be 201, got %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+			return fmt.Errorf("unexpected Content-Type: %s", ct)
+		}
+
+		var parsed Response
+		if err := json.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+			return fmt.Errorf("failed to parse JSON: %v", err)
+		}
+		if parsed.Message != "Created" {
+			return fmt.Errorf("message should be 'Created', got '%s'", parsed.Message)
+		}
+		return nil
+	})
+
+	// Test 10: WriteJsonResponse rejects invalid status codes
+	test("WriteJsonResponse rejects invalid status codes", func() error {
+		rec := httptest.NewRecorder()
+		err := response.WriteJsonResponse(rec, nil, 700, "bad")
+		if err == nil {
+			return fmt.Errorf("should return error for out of range status")
+		}
+		return nil
+	})
+
+	// Test 11: ParseJsonRequest decodes a JSON body
+	test("ParseJsonRequest decodes a JSON body", func() error {
+		body := strings.NewReader(`{"name":"Jane"}`)
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.Header.Set("Content-Type", "application/json")
+
+		var payload struct {
+			Name string `json:"name"`
+		}
+		if err := response.ParseJsonRequest(req, &payload); err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+		if payload.Name != "Jane" {
+			return fmt.Errorf("expected name 'Jane', got '%s'", payload.Name)
+		}
+		return nil
+	})
+
+	// Test 12: ParseJsonRequest rejects unsupported Content-Type
+	test("ParseJsonRequest rejects unsupported Content-Type", func() error {
+		body := strings.NewReader("name=Jane")
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var payload struct{}
+		err := response.ParseJsonRequest(req, &payload)
+		if err == nil {
+			return fmt.Errorf("should return error for unsupported Content-Type")
+		}
+		return nil
+	})
+
+	// Test 13: HumanizeJsonError reports line and character for a syntax error
+	test("HumanizeJsonError reports line and character for a syntax error", func() error {
+		data := []byte("{\n  \"a\": }")
+		var v map[string]interface{}
+		err := json.Unmarshal(data, &v)
+		if err == nil {
+			return fmt.Errorf("expected a syntax error from the test fixture")
+		}
+
+		humanized := response.HumanizeJsonError(err, data)
+		hErr, ok := humanized.(*response.HumanizedJsonError)
+		if !ok {
+			return fmt.Errorf("expected *HumanizedJsonError, got %T", humanized)
+		}
+		if hErr.Line != 2 {
+			return fmt.Errorf("expected line 2, got %d", hErr.Line)
+		}
+		if hErr.Character < 1 {
+			return fmt.Errorf("expected a positive character, got %d", hErr.Character)
+		}
+		if !strings.Contains(hErr.Error(), "line 2, character") {
+			return fmt.Errorf("unexpected error message: %s", hErr.Error())
+		}
+		return nil
+	})
+
+	// Test 14: HumanizeJsonError passes through unrelated errors
+	test("HumanizeJsonError passes through unrelated errors", func() error {
+		original := fmt.Errorf("some other failure")
+		if response.HumanizeJsonError(original, []byte("{}")) != original {
+			return fmt.Errorf("expected the original error to be returned unchanged")
+		}
+		return nil
+	})
+
+	// Test 15: ParseInto surfaces a humanized error on invalid JSON
+	test("ParseInto surfaces a humanized error on invalid JSON", func() error {
+		var v map[string]interface{}
+		err := response.ParseInto([]byte(`{"a": }`), &v)
+		if err == nil {
+			return fmt.Errorf("expected an error for invalid JSON")
+		}
+		if !strings.Contains(err.Error(), "line 1, character") {
+			return fmt.Errorf("expected a humanized message, got: %s", err.Error())
+		}
+		return nil
+	})
+
+	// Test 16: JsonValidationResponse includes field errors
+	test("JsonValidationResponse includes field errors", func() error {
+		fieldErrors := []response.FieldError{
+			{Parameter: "user.email", Errors: []string{"is required"}},
+			{Parameter: "items[0].price", Errors: []string{"must be >= 0"}},
+		}
+		result, err := response.JsonValidationResponse(422, "Validation failed", fieldErrors)
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		var parsed Response
+		if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+			return fmt.Errorf("failed to parse JSON: %v", err)
+		}
+		if parsed.Status != 422 {
+			return fmt.Errorf("status should be 422, got %d", parsed.Status)
+		}
+		if len(parsed.Errors) != 2 {
+			return fmt.Errorf("expected 2 field errors, got %d", len(parsed.Errors))
+		}
+		if parsed.Errors[0].Parameter != "user.email" {
+			return fmt.Errorf("expected nested field path 'user.email', got '%s'", parsed.Errors[0].Parameter)
+		}
+		if parsed.Errors[1].Parameter != "items[0].price" {
+			return fmt.Errorf("expected indexed field path 'items[0].price', got '%s'", parsed.Errors[1].Parameter)
+		}
+		return nil
+	})
+
+	// Test 17: MergeFieldErrors consolidates duplicate parameters
+	test("MergeFieldErrors consolidates duplicate parameters", func() error {
+		merged := response.MergeFieldErrors(
+			response.FieldError{Parameter: "user.email", Errors: []string{"is required"}},
+			response.FieldError{Parameter: "user.email", Errors: []string{"must be a valid email"}},
+			response.FieldError{Parameter: "items[0].price", Errors: []string{"must be >= 0"}},
+		)
+		if len(merged) != 2 {
+			return fmt.Errorf("expected 2 merged entries, got %d", len(merged))
+		}
+		if merged[0].Parameter != "user.email" || len(merged[0].Errors) != 2 {
+			return fmt.Errorf("expected 'user.email' to carry 2 merged messages, got %+v", merged[0])
+		}
+		return nil
+	})
+
+	// Test 18: Response.Error concatenates field errors
+	test("Response.Error concatenates field errors", func() error {
+		r := Response{
+			Message: "Validation failed",
+			Errors: []response.FieldError{
+				{Parameter: "user.email", Errors: []string{"is required"}},
+			},
+		}
+		if !strings.Contains(r.Error(), "user.email") {
+			return fmt.Errorf("expected Error() to mention 'user.email', got: %s", r.Error())
+		}
+		return nil
+	})
+
+	// Test 19: ProblemResponse defaults Status to the given status
+	test("ProblemResponse defaults Status to the given status", func() error {
+		result, err := response.ProblemResponse(404, response.Problem{
+			Type:   "https://example.com/problems/not-found",
+			Title:  "Not Found",
+			Detail: "the requested resource does not exist",
+		})
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		var parsed response.Problem
+		if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+			return fmt.Errorf("failed to parse JSON: %v", err)
+		}
+		if parsed.Status != 404 {
+			return fmt.Errorf("expected Status 404, got %d", parsed.Status)
+		}
+		return nil
+	})
+
+	// Test 20: ProblemTypeRegistry populates Type and Title from a code
+	test("ProblemTypeRegistry populates Type and Title from a code", func() error {
+		registry := response.NewProblemTypeRegistry()
+		registry.Register("processing-failure/destination-exists", "https://example.com/problems/destination-exists", "Destination Already Exists")
+
+		problem := registry.New("processing-failure/destination-exists", 409, "a destination with this name already exists")
+		if problem.Type != "https://example.com/problems/destination-exists" {
+			return fmt.Errorf("unexpected Type: %s", problem.Type)
+		}
+		if problem.Title != "Destination Already Exists" {
+			return fmt.Errorf("unexpected Title: %s", problem.Title)
+		}
+
+		if !response.IsProblemType(problem, "https://example.com/problems/destination-exists") {
+			return fmt.Errorf("expected IsProblemType to match the registered type")
+		}
+		if response.IsProblemType(problem, "https://example.com/problems/something-else") {
+			return fmt.Errorf("expected IsProblemType to reject a different type")
+		}
+		return nil
+	})
+
+	// Test 21: GenerateSchemaForType derives required and min/max from tags
+	test("GenerateSchemaForType derives required and min/max from tags", func() error {
+		type Item struct {
+			Name  string  `json:"name" validate:"required"`
+			Price float64 `json:"price" validate:"required,min=0,max=1000"`
+		}
+
+		s, err := schema.GenerateSchemaForType(reflect.TypeOf(Item{}))
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+		if s.Type != "object" {
+			return fmt.Errorf("expected object schema, got %s", s.Type)
+		}
+		if len(s.Required) != 2 {
+			return fmt.Errorf("expected 2 required fields, got %d", len(s.Required))
+		}
+		priceSchema, ok := s.Properties["price"]
+		if !ok {
+			return fmt.Errorf("expected a 'price' property")
+		}
+		if priceSchema.Minimum == nil || *priceSchema.Minimum != 0 {
+			return fmt.Errorf("expected price minimum 0, got %v", priceSchema.Minimum)
+		}
+		if priceSchema.Maximum == nil || *priceSchema.Maximum != 1000 {
+			return fmt.Errorf("expected price maximum 1000, got %v", priceSchema.Maximum)
+		}
+		return nil
+	})
+
+	// Test 22: JsonResponseWithSchema rejects data that violates the schema
+	test("JsonResponseWithSchema rejects data that violates the schema", func() error {
+		min := 0.0
+		s := &schema.Schema{
+			Type:     "object",
+			Required: []string{"name", "price"},
+			Properties: map[string]*schema.Schema{
+				"name":  {Type: "string"},
+				"price": {Type: "number", Minimum: &min},
+			},
+		}
+
+		result, err := schema.JsonResponseWithSchema(map[string]interface{}{"price": -5}, 200, "Created", s)
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		var parsed Response
+		if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+			return fmt.Errorf("failed to parse JSON: %v", err)
+		}
+		if parsed.Status != 422 {
+			return fmt.Errorf("expected status 422 on validation failure, got %d", parsed.Status)
+		}
+		if len(parsed.Errors) != 2 {
+			return fmt.Errorf("expected 2 field errors (missing name, negative price), got %d", len(parsed.Errors))
+		}
+		return nil
+	})
+
+	// Test 23: JsonResponseWithSchema passes through valid data
+	test("JsonResponseWithSchema passes through valid data", func() error {
+		s := &schema.Schema{
+			Type:     "object",
+			Required: []string{"name"},
+			Properties: map[string]*schema.Schema{
+				"name": {Type: "string"},
+			},
+		}
+
+		result, err := schema.JsonResponseWithSchema(map[string]interface{}{"name": "widget"}, 201, "Created", s)
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		var parsed Response
+		if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+			return fmt.Errorf("failed to parse JSON: %v", err)
+		}
+		if parsed.Status != 201 {
+			return fmt.Errorf("expected status 201, got %d", parsed.Status)
+		}
+		return nil
+	})
+
+	// Test 24: WriteJsonStream emits a metadata line followed by NDJSON items
+	test("WriteJsonStream emits a metadata line followed by NDJSON items", func() error {
+		rec := httptest.NewRecorder()
+		sw, err := response.WriteJsonStream(rec, 200, "Streaming results")
+		if err != nil {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+
+		if err := sw.Write(map[string]interface{}{"id": 1}); err != nil {
+			return fmt.Errorf("unexpected error writing item: %v", err)
+		}
+		if err := sw.Write(map[string]interface{}{"id": 2}); err != nil {
+			return fmt.Errorf("unexpected error writing item: %v", err)
+		}
+		if err := sw.Close(); err != nil {
+			return fmt.Errorf("unexpected error closing stream: %v", err)
+		}
+
+		if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+			return fmt.Errorf("unexpected Content-Type: %s", ct)
+		}
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		if len(lines) != 3 {
+			return fmt.Errorf("expected 3 NDJSON lines (metadata + 2 items), got %d", len(lines))
+		}
+
+		var meta struct {
+			Status  int    `json:"status"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(lines[0]), &meta); err != nil {
+			return fmt.Errorf("failed to parse metadata line: %v", err)
+		}
+		if meta.Status != 200 || meta.Message != "Streaming results" {
+			return fmt.Errorf("unexpected metadata: %+v", meta)
+		}
+		return nil
+	})
+
+	// Test 25: StreamWriter rejects writes after Close
+	test("StreamWriter rejects writes after Close", func() error {
+		rec := httptest.NewRecorder()
+		sw := response.NewJsonStreamWriter(rec, 200, "Streaming results")
+		sw.Close()
+
+		if err := sw.Write(map[string]interface{}{"id": 1}); err == nil {
+			return fmt.Errorf("expected an error writing to a closed stream")
+		}
+		return nil
+	})
+
+	// Test 26: Schema.Validate rejects a nil schema instead of panicking
+	test("Schema.Validate rejects a nil schema instead of panicking", func() error {
+		var s *schema.Schema
+		fieldErrors := s.Validate(map[string]interface{}{"name": "widget"})
+		if len(fieldErrors) != 1 {
+			return fmt.Errorf("expected 1 field error for a nil schema, got %d", len(fieldErrors))
+		}
+		return nil
+	})
+
+	// Test 27: JsonResponseWithSchema rejects a nil schema instead of panicking
+	test("JsonResponseWithSchema rejects a nil schema instead of panicking", func() error {
+		_, err := schema.JsonResponseWithSchema(map[string]interface{}{"name": "widget"}, 200, "Created", nil)
+		if err == nil {
+			return fmt.Errorf("expected an error for a nil schema")
+		}
+		return nil
+	})
+
+	// Test 28: array schema without Items reports an error instead of panicking
+	test("array schema without Items reports an error instead of panicking", func() error {
+		s := &schema.Schema{Type: "array"}
+		fieldErrors := s.Validate([]interface{}{1, 2, 3})
+		if len(fieldErrors) != 1 {
+			return fmt.Errorf("expected 1 field error for a missing Items schema, got %d", len(fieldErrors))
+		}
+		return nil
+	})
+
+	// Test 29: integer schema rejects non-integral numbers
+	test("integer schema rejects non-integral numbers", func() error {
+		s := &schema.Schema{
+			Type:       "object",
+			Properties: map[string]*schema.Schema{"count": {Type: "integer"}},
+		}
+		fieldErrors := s.Validate(map[string]interface{}{"count": 1.5})
+		if len(fieldErrors) != 1 {
+			return fmt.Errorf("expected 1 field error for a non-integral count, got %d", len(fieldErrors))
+		}
+
+		fieldErrors = s.Validate(map[string]interface{}{"count": 2})
+		if len(fieldErrors) != 0 {
+			return fmt.Errorf("expected no field errors for an integral count, got %d", len(fieldErrors))
+		}
+		return nil
+	})
+
 	// Summary
 	fmt.Printf("\nTests completed! %d/%d tests passed.\n", passedTests, totalTests)
 